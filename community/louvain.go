@@ -0,0 +1,242 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+// ReducedGraph is a graph.Graph whose nodes are the communities found at
+// one level of the Louvain hierarchy. An edge between two communities
+// carries the summed weight of the edges that crossed between them before
+// aggregation; a self-loop carries the summed weight of edges that were
+// internal to the community.
+type ReducedGraph struct {
+	g           *concrete.MutableDirectedGraph
+	expanded    graph.Graph
+	communities [][]graph.Node
+}
+
+// Expanded returns the graph that this ReducedGraph was aggregated from,
+// i.e. the level below it in the Louvain hierarchy.
+func (r *ReducedGraph) Expanded() graph.Graph {
+	return r.expanded
+}
+
+// Communities returns the grouping of nodes of Expanded that produced this
+// level's nodes, indexed so that community i collapsed to the node with
+// ID i.
+func (r *ReducedGraph) Communities() [][]graph.Node {
+	out := make([][]graph.Node, len(r.communities))
+	copy(out, r.communities)
+	return out
+}
+
+func (r *ReducedGraph) Successors(n graph.Node) []graph.Node   { return r.g.Successors(n) }
+func (r *ReducedGraph) Predecessors(n graph.Node) []graph.Node { return r.g.Predecessors(n) }
+func (r *ReducedGraph) Neighbors(n graph.Node) []graph.Node    { return r.g.Neighbors(n) }
+func (r *ReducedGraph) EdgeBetween(n, neigh graph.Node) graph.Edge {
+	return r.g.EdgeBetween(n, neigh)
+}
+func (r *ReducedGraph) EdgeTo(n, succ graph.Node) graph.Edge { return r.g.EdgeTo(n, succ) }
+func (r *ReducedGraph) NodeExists(n graph.Node) bool         { return r.g.NodeExists(n) }
+func (r *ReducedGraph) Degree(n graph.Node) int              { return r.g.Degree(n) }
+func (r *ReducedGraph) NodeList() []graph.Node               { return r.g.NodeList() }
+func (r *ReducedGraph) Cost(e graph.Edge) float64            { return r.g.Cost(e) }
+func (r *ReducedGraph) EdgeList() []graph.Edge               { return r.g.EdgeList() }
+
+// Louvain computes a hierarchy of community aggregations of g, a directed
+// graph, using the multilevel Louvain method, and returns the coarsest
+// level reached. g's edges are used as given: an undirected graph must be
+// supplied as a symmetric directed graph (each edge mirrored in both
+// directions) for its degrees and modularity to come out right. Each
+// level alternates a local-moving phase, which greedily reassigns nodes to
+// the neighboring community that most improves modularity, with an
+// aggregation phase, which collapses each community into a single node of
+// the next level's ReducedGraph. The two phases repeat until a pass of
+// local moving no longer improves modularity. resolution is passed through
+// to Modularity, with 1 recovering the standard definition. rnd shuffles
+// the order nodes are visited during local moving; with a nil rnd, nodes
+// are visited unshuffled in the order g.NodeList returns them, which is
+// not a fixed order for graphs whose NodeList follows map iteration, such
+// as concrete.MutableDirectedGraph.
+//
+// Walk Expanded on the result to recover finer levels of the hierarchy,
+// down to g itself, and Communities to recover the grouping that produced
+// each level.
+func Louvain(g graph.Graph, resolution float64, rnd *rand.Rand) *ReducedGraph {
+	current := g
+	var best *ReducedGraph
+	prevQ := math.Inf(-1)
+	for {
+		communities := localMove(current, resolution, rnd)
+		q := Modularity(current, communities, resolution)
+		if q <= prevQ && best != nil {
+			break
+		}
+		prevQ = q
+		best = aggregate(current, communities)
+		if len(communities) == len(current.NodeList()) {
+			// No two nodes merged this round; further levels would be
+			// isomorphic to this one.
+			break
+		}
+		current = best
+	}
+	return best
+}
+
+// localMove performs the local-moving phase of the Louvain method. Every
+// node starts in its own singleton community; then, in randomized order,
+// each node is moved into whichever neighboring community yields the
+// largest positive gain in modularity. Per-community in/out weight totals
+// are maintained incrementally so each node's move is evaluated in
+// O(deg(u)) rather than by rescanning its community. The phase repeats
+// until a full pass leaves every node in place.
+func localMove(g graph.Graph, resolution float64, rnd *rand.Rand) [][]graph.Node {
+	nodes := g.NodeList()
+	commOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		commOf[n.ID()] = i
+	}
+
+	var m float64
+	outDeg := make(map[int]float64, len(nodes))
+	inDeg := make(map[int]float64, len(nodes))
+	for _, u := range nodes {
+		for _, v := range g.Successors(u) {
+			w := g.Cost(g.EdgeTo(u, v))
+			m += w
+			outDeg[u.ID()] += w
+			inDeg[v.ID()] += w
+		}
+	}
+	if m == 0 {
+		return singletons(nodes)
+	}
+
+	sigmaOut := make(map[int]float64, len(nodes))
+	sigmaIn := make(map[int]float64, len(nodes))
+	for _, n := range nodes {
+		sigmaOut[commOf[n.ID()]] += outDeg[n.ID()]
+		sigmaIn[commOf[n.ID()]] += inDeg[n.ID()]
+	}
+
+	order := make([]int, len(nodes))
+	for i := range order {
+		order[i] = i
+	}
+
+	for moved := true; moved; {
+		moved = false
+		if rnd != nil {
+			rnd.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		}
+		for _, idx := range order {
+			u := nodes[idx]
+			uID := u.ID()
+			cur := commOf[uID]
+
+			weightTo := make(map[int]float64)
+			for _, e := range incident(g, u) {
+				other := e.Head()
+				if other.ID() == uID {
+					other = e.Tail()
+				}
+				weightTo[commOf[other.ID()]] += g.Cost(e)
+			}
+
+			sigmaOut[cur] -= outDeg[uID]
+			sigmaIn[cur] -= inDeg[uID]
+
+			best, bestGain := cur, 0.0
+			for c, w := range weightTo {
+				gain := w - resolution*(outDeg[uID]*sigmaIn[c]+inDeg[uID]*sigmaOut[c])/m
+				if gain > bestGain {
+					best, bestGain = c, gain
+				}
+			}
+
+			sigmaOut[best] += outDeg[uID]
+			sigmaIn[best] += inDeg[uID]
+			if best != cur {
+				commOf[uID] = best
+				moved = true
+			}
+		}
+	}
+
+	byComm := make(map[int][]graph.Node)
+	for _, n := range nodes {
+		c := commOf[n.ID()]
+		byComm[c] = append(byComm[c], n)
+	}
+	communities := make([][]graph.Node, 0, len(byComm))
+	for _, members := range byComm {
+		communities = append(communities, members)
+	}
+	return communities
+}
+
+// incident returns every edge touching n, counting a self-loop once.
+func incident(g graph.Graph, n graph.Node) []graph.Edge {
+	edges := make([]graph.Edge, 0, g.Degree(n))
+	for _, s := range g.Successors(n) {
+		edges = append(edges, g.EdgeTo(n, s))
+	}
+	for _, p := range g.Predecessors(n) {
+		if p.ID() == n.ID() {
+			continue // already counted via Successors
+		}
+		edges = append(edges, g.EdgeTo(p, n))
+	}
+	return edges
+}
+
+func singletons(nodes []graph.Node) [][]graph.Node {
+	out := make([][]graph.Node, len(nodes))
+	for i, n := range nodes {
+		out[i] = []graph.Node{n}
+	}
+	return out
+}
+
+// aggregate builds the next level of the Louvain hierarchy: one node per
+// community, with an edge between two community-nodes weighing the total
+// weight of edges that crossed between the corresponding communities in g,
+// and a self-loop weighing the total weight of edges internal to the
+// community.
+func aggregate(g graph.Graph, communities [][]graph.Node) *ReducedGraph {
+	reduced := concrete.NewMutableDirectedGraph()
+	nodeOf := make([]graph.Node, len(communities))
+	commOf := make(map[int]int)
+	for i, members := range communities {
+		n := concrete.Node(i)
+		reduced.AddNode(n)
+		nodeOf[i] = n
+		for _, m := range members {
+			commOf[m.ID()] = i
+		}
+	}
+
+	type pair struct{ from, to int }
+	weight := make(map[pair]float64)
+	for _, u := range g.NodeList() {
+		for _, v := range g.Successors(u) {
+			key := pair{commOf[u.ID()], commOf[v.ID()]}
+			weight[key] += g.Cost(g.EdgeTo(u, v))
+		}
+	}
+
+	for key, w := range weight {
+		reduced.AddEdgeTo(concrete.Edge{H: nodeOf[key.from], T: nodeOf[key.to]}, w)
+	}
+
+	return &ReducedGraph{g: reduced, expanded: g, communities: communities}
+}
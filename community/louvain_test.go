@@ -0,0 +1,83 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+func ids(nodes []graph.Node) []int {
+	out := make([]int, len(nodes))
+	for i, n := range nodes {
+		out[i] = n.ID()
+	}
+	sort.Ints(out)
+	return out
+}
+
+func idSets(communities [][]graph.Node) [][]int {
+	out := make([][]int, len(communities))
+	for i, c := range communities {
+		out[i] = ids(c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// TestLouvainMergesReciprocalPair checks that a single pair of nodes
+// linked by reciprocal edges is merged into one community: two singleton
+// communities give Q=-0.5, merging them gives Q=0, so merging strictly
+// improves modularity and localMove must take it.
+func TestLouvainMergesReciprocalPair(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	a, b := concrete.Node(0), concrete.Node(1)
+	g.AddEdgeTo(concrete.Edge{H: a, T: b}, 1)
+	g.AddEdgeTo(concrete.Edge{H: b, T: a}, 1)
+
+	got := idSets(Louvain(g, 1, nil).Communities())
+	want := [][]int{{0, 1}}
+	if !equalIDSets(got, want) {
+		t.Errorf("Communities() = %v, want %v", got, want)
+	}
+}
+
+// TestLouvainIsolatedNodeStaysSingleton checks the documented edge case
+// that an isolated node, having no edge whose gain could ever exceed the
+// zero-gain threshold of staying put, is left in its own community even
+// while its neighbors merge.
+func TestLouvainIsolatedNodeStaysSingleton(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	a, b, iso := concrete.Node(0), concrete.Node(1), concrete.Node(2)
+	g.AddEdgeTo(concrete.Edge{H: a, T: b}, 1)
+	g.AddEdgeTo(concrete.Edge{H: b, T: a}, 1)
+	g.AddNode(iso)
+
+	got := idSets(Louvain(g, 1, nil).Communities())
+	want := [][]int{{0, 1}, {2}}
+	if !equalIDSets(got, want) {
+		t.Errorf("Communities() = %v, want %v", got, want)
+	}
+}
+
+func equalIDSets(a, b [][]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package community implements community detection over directed
+// graph.Graph implementations, including multilevel modularity
+// optimization via the Louvain method.
+package community
+
+import "github.com/gonum/graph"
+
+// Modularity returns the modularity of the partition of g described by
+// communities, a set of disjoint groups of nodes that together cover every
+// node in g. For resolution 1, this computes the standard modularity
+//
+//	Q = (1/m) Σ_ij [A_ij - γ·k_i^out·k_j^in/m] δ(c_i,c_j)
+//
+// where A is the weighted adjacency matrix of g, m is the total edge
+// weight in g, k_i^out and k_i^in are the out- and in-weighted degrees of
+// node i, γ is resolution, and δ(c_i,c_j) is 1 when i and j belong to the
+// same community and 0 otherwise. Values of resolution greater than 1
+// penalize large communities more heavily, favoring a finer partition.
+func Modularity(g graph.Graph, communities [][]graph.Node, resolution float64) float64 {
+	community := make(map[int]int)
+	for i, c := range communities {
+		for _, n := range c {
+			community[n.ID()] = i
+		}
+	}
+
+	var m float64
+	out := make(map[int]float64)
+	in := make(map[int]float64)
+	// Edges are walked through Successors rather than EdgeList, since
+	// concrete.MutableDirectedGraph's EdgeList reports only one edge of a
+	// reciprocal pair.
+	for _, u := range g.NodeList() {
+		for _, v := range g.Successors(u) {
+			w := g.Cost(g.EdgeTo(u, v))
+			m += w
+			out[u.ID()] += w
+			in[v.ID()] += w
+		}
+	}
+	if m == 0 {
+		return 0
+	}
+
+	sigmaOut := make(map[int]float64, len(communities))
+	sigmaIn := make(map[int]float64, len(communities))
+	for i, c := range communities {
+		for _, n := range c {
+			sigmaOut[i] += out[n.ID()]
+			sigmaIn[i] += in[n.ID()]
+		}
+	}
+
+	var q float64
+	for _, u := range g.NodeList() {
+		for _, v := range g.Successors(u) {
+			if community[u.ID()] == community[v.ID()] {
+				q += g.Cost(g.EdgeTo(u, v))
+			}
+		}
+	}
+	for i := range communities {
+		q -= resolution * sigmaOut[i] * sigmaIn[i] / m
+	}
+	return q / m
+}
@@ -0,0 +1,46 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+// TestModularityPathAllOneCommunity checks the case from the review that
+// exposed the original bug: a directed path a->b->c has no edges outside
+// the single community containing all three nodes, so the null model must
+// exactly cancel the observed term and Q must be 0.
+func TestModularityPathAllOneCommunity(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	a, b, c := concrete.Node(0), concrete.Node(1), concrete.Node(2)
+	g.AddEdgeTo(concrete.Edge{H: a, T: b}, 1)
+	g.AddEdgeTo(concrete.Edge{H: b, T: c}, 1)
+
+	communities := [][]graph.Node{{a, b, c}}
+	if got := Modularity(g, communities, 1); got != 0 {
+		t.Errorf("Modularity of a single all-encompassing community = %v, want 0", got)
+	}
+}
+
+// TestModularitySingletons checks a partition where every node is its own
+// community, so the null model term must be taken over every intra-node
+// (i.e. self) pair, none of which are edges.
+func TestModularitySingletons(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	a, b, c := concrete.Node(0), concrete.Node(1), concrete.Node(2)
+	g.AddEdgeTo(concrete.Edge{H: a, T: b}, 1)
+	g.AddEdgeTo(concrete.Edge{H: b, T: c}, 1)
+
+	communities := [][]graph.Node{{a}, {b}, {c}}
+	// m=2, out={a:1,b:1,c:0}, in={a:0,b:1,c:1}; each singleton's
+	// null-model term is out[n]*in[n]/m, and no edge is intra-community.
+	want := -1 * (1.0*0 + 1.0*1 + 0.0*1) / 2 / 2
+	if got := Modularity(g, communities, 1); got != want {
+		t.Errorf("Modularity of an all-singleton partition = %v, want %v", got, want)
+	}
+}
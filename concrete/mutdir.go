@@ -9,6 +9,7 @@ import (
 	"sort"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/iterator"
 )
 
 // A GonumGraph is a very generalized graph that can handle an arbitrary number of vertices and
@@ -248,4 +249,56 @@ func (g *MutableDirectedGraph) EdgeList() []graph.Edge {
 	}
 
 	return edgeList
-}
\ No newline at end of file
+}
+
+/* Lazy iterator API */
+
+// FromIter returns a graph.Nodes over the successors of n, resolving each
+// one from the underlying node map only as it is visited. Unlike
+// Successors, it never allocates a []graph.Node, or even a []int of the
+// successor IDs: it walks g.successors[n.ID()] directly.
+func (g *MutableDirectedGraph) FromIter(n graph.Node) graph.Nodes {
+	return iterator.NewLazyMapNodes(g.successors[n.ID()], g.lookup)
+}
+
+// ToIter is the Predecessors analogue of FromIter.
+func (g *MutableDirectedGraph) ToIter(n graph.Node) graph.Nodes {
+	return iterator.NewLazyMapNodes(g.predecessors[n.ID()], g.lookup)
+}
+
+// NeighborsIter is the Neighbors analogue of FromIter: it chains an
+// iterator over g.successors[n.ID()] with one over g.predecessors[n.ID()]
+// that skips IDs already seen as a successor, so no ID appears twice and
+// no slice of the union is ever built.
+func (g *MutableDirectedGraph) NeighborsIter(n graph.Node) graph.Nodes {
+	succ := g.successors[n.ID()]
+	pred := g.predecessors[n.ID()]
+
+	alreadySucc := func(id int) bool {
+		_, ok := succ[id]
+		return ok
+	}
+	return iterator.NewChainNodes(
+		iterator.NewLazyMapNodes(succ, g.lookup),
+		iterator.NewFilterMapNodes(pred, alreadySucc, g.lookup),
+	)
+}
+
+// NodesIter is the NodeList analogue of FromIter: it walks g.nodeMap
+// directly instead of copying every node into a slice up front.
+func (g *MutableDirectedGraph) NodesIter() graph.Nodes {
+	return iterator.NewLazyMapNodes(g.nodeMap, g.lookup)
+}
+
+// EdgesIter is the EdgeList analogue of FromIter. Unlike the node
+// iterators above, it is not lazy: it wraps the slice built by EdgeList.
+func (g *MutableDirectedGraph) EdgesIter() graph.Edges {
+	return iterator.NewOrderedEdges(g.EdgeList())
+}
+
+// lookup resolves id to the graph.Node g registered it under. It returns
+// nil if id is not a node of g; the lazy iterators above only ever call it
+// with an ID drawn from g's own maps.
+func (g *MutableDirectedGraph) lookup(id int) graph.Node {
+	return g.nodeMap[id]
+}
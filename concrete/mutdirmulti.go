@@ -0,0 +1,366 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package concrete
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// MultiEdge is a graph.Edge that can be distinguished from other edges
+// sharing the same head and tail by a unique ID. It is the edge type
+// produced by MutableDirectedMultigraph, where more than one edge may
+// connect the same pair of nodes.
+type MultiEdge interface {
+	graph.Edge
+	EdgeID() int
+}
+
+// Line is the MultiEdge implementation used by MutableDirectedMultigraph.
+type Line struct {
+	F, T graph.Node
+	ID   int
+}
+
+func (l Line) Head() graph.Node { return l.F }
+func (l Line) Tail() graph.Node { return l.T }
+func (l Line) EdgeID() int      { return l.ID }
+
+// CostFunc reduces the costs of the parallel edges between a pair of
+// nodes to the single value a MutableDirectedMultigraph reports through
+// Cost when asked about the pair generically rather than about one
+// specific Line.
+type CostFunc func(costs []float64) float64
+
+// MinCost is the default CostFunc: the cost of the cheapest parallel edge.
+func MinCost(costs []float64) float64 {
+	c := math.Inf(1)
+	for _, w := range costs {
+		if w < c {
+			c = w
+		}
+	}
+	return c
+}
+
+// MaxCost is a CostFunc returning the cost of the most expensive parallel
+// edge.
+func MaxCost(costs []float64) float64 {
+	c := math.Inf(-1)
+	for _, w := range costs {
+		if w > c {
+			c = w
+		}
+	}
+	return c
+}
+
+// SumCost is a CostFunc returning the combined cost of all parallel
+// edges.
+func SumCost(costs []float64) float64 {
+	var c float64
+	for _, w := range costs {
+		c += w
+	}
+	return c
+}
+
+// MutableDirectedMultigraph is a MutableDirectedGraph that allows more
+// than one edge between the same pair of nodes, each carrying its own
+// weight and identified by its own EdgeID. It is suited to modeling
+// transportation networks with several routes between two stops, call
+// graphs with multiple call sites between the same two functions, or
+// other domains where parallel edges carry independent meaning.
+type MutableDirectedMultigraph struct {
+	successors   map[int]map[int][]WeightedEdge
+	predecessors map[int]map[int][]WeightedEdge
+	nodeMap      map[int]graph.Node
+	lines        map[int]Line
+	nextEdgeID   int
+	cost         CostFunc
+}
+
+// NewMutableDirectedMultigraph returns an empty MutableDirectedMultigraph
+// whose generic Cost queries are resolved with MinCost. Use SetCostFunc to
+// choose a different reduction.
+func NewMutableDirectedMultigraph() *MutableDirectedMultigraph {
+	return &MutableDirectedMultigraph{
+		successors:   make(map[int]map[int][]WeightedEdge),
+		predecessors: make(map[int]map[int][]WeightedEdge),
+		nodeMap:      make(map[int]graph.Node),
+		lines:        make(map[int]Line),
+		cost:         MinCost,
+	}
+}
+
+// SetCostFunc sets the reduction used to answer Cost queries that are not
+// about one specific Line.
+func (g *MutableDirectedMultigraph) SetCostFunc(f CostFunc) {
+	g.cost = f
+}
+
+/* Mutable graph implementation */
+
+func (g *MutableDirectedMultigraph) AddNode(n graph.Node) {
+	if _, ok := g.nodeMap[n.ID()]; ok {
+		return
+	}
+
+	g.nodeMap[n.ID()] = n
+	g.successors[n.ID()] = make(map[int][]WeightedEdge)
+	g.predecessors[n.ID()] = make(map[int][]WeightedEdge)
+}
+
+// NewEdge returns a new, uniquely identified parallel edge from head to
+// tail with cost 0, and adds it to g. Use AddEdgeTo on the result to set
+// its cost, or RemoveLine to remove it.
+func (g *MutableDirectedMultigraph) NewEdge(head, tail graph.Node) graph.Edge {
+	g.AddNode(head)
+	g.AddNode(tail)
+
+	l := Line{F: head, T: tail, ID: g.nextEdgeID}
+	g.nextEdgeID++
+	g.lines[l.ID] = l
+	g.addLine(l, 0)
+	return l
+}
+
+// AddEdgeTo sets the cost of e if e is a Line already in g, such as one
+// returned by NewEdge or Lines. Otherwise it adds e as a new parallel edge
+// between e.Head() and e.Tail() with the given cost and a newly assigned
+// ID.
+func (g *MutableDirectedMultigraph) AddEdgeTo(e graph.Edge, cost float64) {
+	head, tail := e.Head(), e.Tail()
+	g.AddNode(head)
+	g.AddNode(tail)
+
+	l, ok := e.(Line)
+	if !ok || g.lines[l.ID] != l {
+		l = Line{F: head, T: tail, ID: g.nextEdgeID}
+		g.nextEdgeID++
+	}
+	g.lines[l.ID] = l
+	g.addLine(l, cost)
+}
+
+func (g *MutableDirectedMultigraph) addLine(l Line, cost float64) {
+	we := WeightedEdge{Edge: l, Cost: cost}
+	g.successors[l.F.ID()][l.T.ID()] = upsertLine(g.successors[l.F.ID()][l.T.ID()], we)
+	g.predecessors[l.T.ID()][l.F.ID()] = upsertLine(g.predecessors[l.T.ID()][l.F.ID()], we)
+}
+
+// upsertLine records we among lines, replacing any existing entry with the
+// same EdgeID in place rather than adding a second parallel line for it.
+// This is what lets AddEdgeTo be called again on a Line, e.g. the one
+// returned by NewEdge, to change its cost.
+func upsertLine(lines []WeightedEdge, we WeightedEdge) []WeightedEdge {
+	id := we.Edge.(Line).ID
+	for i, existing := range lines {
+		if existing.Edge.(Line).ID == id {
+			lines[i] = we
+			return lines
+		}
+	}
+	return append(lines, we)
+}
+
+// RemoveLine removes e, which must be one of g's Lines, such as one
+// returned by NewEdge or Lines, from g. It is a no-op if e is not one of
+// g's lines.
+func (g *MutableDirectedMultigraph) RemoveLine(e graph.Edge) {
+	l, ok := e.(Line)
+	if !ok {
+		return
+	}
+	if _, ok := g.lines[l.ID]; !ok {
+		return
+	}
+	delete(g.lines, l.ID)
+
+	g.successors[l.F.ID()][l.T.ID()] = removeLine(g.successors[l.F.ID()][l.T.ID()], l.ID)
+	g.predecessors[l.T.ID()][l.F.ID()] = removeLine(g.predecessors[l.T.ID()][l.F.ID()], l.ID)
+}
+
+func removeLine(lines []WeightedEdge, id int) []WeightedEdge {
+	for i, we := range lines {
+		if we.Edge.(Line).ID == id {
+			return append(lines[:i], lines[i+1:]...)
+		}
+	}
+	return lines
+}
+
+func (g *MutableDirectedMultigraph) RemoveNode(n graph.Node) {
+	if _, ok := g.nodeMap[n.ID()]; !ok {
+		return
+	}
+	delete(g.nodeMap, n.ID())
+
+	for succ, lines := range g.successors[n.ID()] {
+		for _, we := range lines {
+			delete(g.lines, we.Edge.(Line).ID)
+		}
+		delete(g.predecessors[succ], n.ID())
+	}
+	delete(g.successors, n.ID())
+
+	for pred, lines := range g.predecessors[n.ID()] {
+		for _, we := range lines {
+			delete(g.lines, we.Edge.(Line).ID)
+		}
+		delete(g.successors[pred], n.ID())
+	}
+	delete(g.predecessors, n.ID())
+}
+
+func (g *MutableDirectedMultigraph) EmptyGraph() {
+	g.successors = make(map[int]map[int][]WeightedEdge)
+	g.predecessors = make(map[int]map[int][]WeightedEdge)
+	g.nodeMap = make(map[int]graph.Node)
+	g.lines = make(map[int]Line)
+}
+
+/* Graph implementation */
+
+// Lines returns every parallel edge from head to tail, or nil if there is
+// none.
+func (g *MutableDirectedMultigraph) Lines(head, tail graph.Node) []graph.Edge {
+	lines, ok := g.successors[head.ID()][tail.ID()]
+	if !ok || len(lines) == 0 {
+		return nil
+	}
+	edges := make([]graph.Edge, len(lines))
+	for i, we := range lines {
+		edges[i] = we.Edge
+	}
+	return edges
+}
+
+func (g *MutableDirectedMultigraph) Successors(n graph.Node) []graph.Node {
+	succMap, ok := g.successors[n.ID()]
+	if !ok {
+		return nil
+	}
+
+	successors := make([]graph.Node, 0, len(succMap))
+	for succ := range succMap {
+		successors = append(successors, g.nodeMap[succ])
+	}
+	return successors
+}
+
+// EdgeTo returns an arbitrary representative parallel edge from n to
+// succ, or nil if there is none. Use Lines for the full parallel set.
+func (g *MutableDirectedMultigraph) EdgeTo(n, succ graph.Node) graph.Edge {
+	lines, ok := g.successors[n.ID()][succ.ID()]
+	if !ok || len(lines) == 0 {
+		return nil
+	}
+	return lines[0].Edge
+}
+
+func (g *MutableDirectedMultigraph) Predecessors(n graph.Node) []graph.Node {
+	predMap, ok := g.predecessors[n.ID()]
+	if !ok {
+		return nil
+	}
+
+	predecessors := make([]graph.Node, 0, len(predMap))
+	for pred := range predMap {
+		predecessors = append(predecessors, g.nodeMap[pred])
+	}
+	return predecessors
+}
+
+func (g *MutableDirectedMultigraph) Neighbors(n graph.Node) []graph.Node {
+	succMap, ok := g.successors[n.ID()]
+	if !ok {
+		return nil
+	}
+	predMap := g.predecessors[n.ID()]
+
+	neighbors := make([]graph.Node, 0, len(succMap)+len(predMap))
+	for succ := range succMap {
+		neighbors = append(neighbors, g.nodeMap[succ])
+	}
+	for pred := range predMap {
+		if _, ok := succMap[pred]; !ok {
+			neighbors = append(neighbors, g.nodeMap[pred])
+		}
+	}
+	return neighbors
+}
+
+func (g *MutableDirectedMultigraph) EdgeBetween(n, neigh graph.Node) graph.Edge {
+	if e := g.EdgeTo(n, neigh); e != nil {
+		return e
+	}
+	return g.EdgeTo(neigh, n)
+}
+
+func (g *MutableDirectedMultigraph) NodeExists(n graph.Node) bool {
+	_, ok := g.nodeMap[n.ID()]
+	return ok
+}
+
+func (g *MutableDirectedMultigraph) Degree(n graph.Node) int {
+	if _, ok := g.nodeMap[n.ID()]; !ok {
+		return 0
+	}
+
+	var d int
+	for _, lines := range g.successors[n.ID()] {
+		d += len(lines)
+	}
+	for _, lines := range g.predecessors[n.ID()] {
+		d += len(lines)
+	}
+	return d
+}
+
+func (g *MutableDirectedMultigraph) NodeList() []graph.Node {
+	nodes := make([]graph.Node, 0, len(g.nodeMap))
+	for _, n := range g.nodeMap {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Cost returns the weight of e if e is one of g's Lines. Otherwise it
+// reduces the weights of every parallel edge between e.Head() and
+// e.Tail() with g's CostFunc (MinCost by default).
+func (g *MutableDirectedMultigraph) Cost(e graph.Edge) float64 {
+	lines, ok := g.successors[e.Head().ID()][e.Tail().ID()]
+	if !ok || len(lines) == 0 {
+		return math.Inf(1)
+	}
+
+	if l, ok := e.(Line); ok {
+		for _, we := range lines {
+			if we.Edge.(Line).ID == l.ID {
+				return we.Cost
+			}
+		}
+	}
+
+	costs := make([]float64, len(lines))
+	for i, we := range lines {
+		costs[i] = we.Cost
+	}
+	return g.cost(costs)
+}
+
+func (g *MutableDirectedMultigraph) EdgeList() []graph.Edge {
+	var edges []graph.Edge
+	for _, succMap := range g.successors {
+		for _, lines := range succMap {
+			for _, we := range lines {
+				edges = append(edges, we.Edge)
+			}
+		}
+	}
+	return edges
+}
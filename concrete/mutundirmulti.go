@@ -0,0 +1,242 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package concrete
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// MutableUndirectedMultigraph is the undirected counterpart of
+// MutableDirectedMultigraph: more than one edge may connect the same pair
+// of nodes, each with its own weight and EdgeID, but an edge between u and
+// v is indistinguishable from one between v and u.
+type MutableUndirectedMultigraph struct {
+	edges      map[int]map[int][]WeightedEdge
+	nodeMap    map[int]graph.Node
+	lines      map[int]Line
+	nextEdgeID int
+	cost       CostFunc
+}
+
+// NewMutableUndirectedMultigraph returns an empty
+// MutableUndirectedMultigraph whose generic Cost queries are resolved
+// with MinCost. Use SetCostFunc to choose a different reduction.
+func NewMutableUndirectedMultigraph() *MutableUndirectedMultigraph {
+	return &MutableUndirectedMultigraph{
+		edges:   make(map[int]map[int][]WeightedEdge),
+		nodeMap: make(map[int]graph.Node),
+		lines:   make(map[int]Line),
+		cost:    MinCost,
+	}
+}
+
+// SetCostFunc sets the reduction used to answer Cost queries that are not
+// about one specific Line.
+func (g *MutableUndirectedMultigraph) SetCostFunc(f CostFunc) {
+	g.cost = f
+}
+
+func (g *MutableUndirectedMultigraph) AddNode(n graph.Node) {
+	if _, ok := g.nodeMap[n.ID()]; ok {
+		return
+	}
+
+	g.nodeMap[n.ID()] = n
+	g.edges[n.ID()] = make(map[int][]WeightedEdge)
+}
+
+// NewEdge returns a new, uniquely identified parallel edge between u and v
+// with cost 0, and adds it to g.
+func (g *MutableUndirectedMultigraph) NewEdge(u, v graph.Node) graph.Edge {
+	g.AddNode(u)
+	g.AddNode(v)
+
+	l := Line{F: u, T: v, ID: g.nextEdgeID}
+	g.nextEdgeID++
+	g.lines[l.ID] = l
+	g.addLine(l, 0)
+	return l
+}
+
+// AddEdgeTo sets the cost of e if e is a Line already in g, such as one
+// returned by NewEdge or Lines. Otherwise it adds e as a new parallel edge
+// between e.Head() and e.Tail() with the given cost and a newly assigned
+// ID.
+func (g *MutableUndirectedMultigraph) AddEdgeTo(e graph.Edge, cost float64) {
+	u, v := e.Head(), e.Tail()
+	g.AddNode(u)
+	g.AddNode(v)
+
+	l, ok := e.(Line)
+	if !ok || g.lines[l.ID] != l {
+		l = Line{F: u, T: v, ID: g.nextEdgeID}
+		g.nextEdgeID++
+	}
+	g.lines[l.ID] = l
+	g.addLine(l, cost)
+}
+
+func (g *MutableUndirectedMultigraph) addLine(l Line, cost float64) {
+	we := WeightedEdge{Edge: l, Cost: cost}
+	g.edges[l.F.ID()][l.T.ID()] = upsertLine(g.edges[l.F.ID()][l.T.ID()], we)
+	if l.T.ID() != l.F.ID() {
+		g.edges[l.T.ID()][l.F.ID()] = upsertLine(g.edges[l.T.ID()][l.F.ID()], we)
+	}
+}
+
+// Lines returns every parallel edge between u and v, or nil if there is
+// none.
+func (g *MutableUndirectedMultigraph) Lines(u, v graph.Node) []graph.Edge {
+	lines, ok := g.edges[u.ID()][v.ID()]
+	if !ok || len(lines) == 0 {
+		return nil
+	}
+	edges := make([]graph.Edge, len(lines))
+	for i, we := range lines {
+		edges[i] = we.Edge
+	}
+	return edges
+}
+
+// RemoveLine removes e, which must be one of g's Lines, such as one
+// returned by NewEdge or Lines, from g. It is a no-op if e is not one of
+// g's lines.
+func (g *MutableUndirectedMultigraph) RemoveLine(e graph.Edge) {
+	l, ok := e.(Line)
+	if !ok {
+		return
+	}
+	if _, ok := g.lines[l.ID]; !ok {
+		return
+	}
+	delete(g.lines, l.ID)
+
+	g.edges[l.F.ID()][l.T.ID()] = removeLine(g.edges[l.F.ID()][l.T.ID()], l.ID)
+	if l.T.ID() != l.F.ID() {
+		g.edges[l.T.ID()][l.F.ID()] = removeLine(g.edges[l.T.ID()][l.F.ID()], l.ID)
+	}
+}
+
+func (g *MutableUndirectedMultigraph) RemoveNode(n graph.Node) {
+	if _, ok := g.nodeMap[n.ID()]; !ok {
+		return
+	}
+	delete(g.nodeMap, n.ID())
+
+	for neigh, lines := range g.edges[n.ID()] {
+		for _, we := range lines {
+			delete(g.lines, we.Edge.(Line).ID)
+		}
+		if neigh != n.ID() {
+			delete(g.edges[neigh], n.ID())
+		}
+	}
+	delete(g.edges, n.ID())
+}
+
+func (g *MutableUndirectedMultigraph) EmptyGraph() {
+	g.edges = make(map[int]map[int][]WeightedEdge)
+	g.nodeMap = make(map[int]graph.Node)
+	g.lines = make(map[int]Line)
+}
+
+func (g *MutableUndirectedMultigraph) Successors(n graph.Node) []graph.Node   { return g.Neighbors(n) }
+func (g *MutableUndirectedMultigraph) Predecessors(n graph.Node) []graph.Node { return g.Neighbors(n) }
+
+func (g *MutableUndirectedMultigraph) Neighbors(n graph.Node) []graph.Node {
+	neighMap, ok := g.edges[n.ID()]
+	if !ok {
+		return nil
+	}
+
+	neighbors := make([]graph.Node, 0, len(neighMap))
+	for id := range neighMap {
+		neighbors = append(neighbors, g.nodeMap[id])
+	}
+	return neighbors
+}
+
+// EdgeTo returns an arbitrary representative parallel edge between n and
+// neigh, or nil if there is none. Use Lines for the full parallel set.
+func (g *MutableUndirectedMultigraph) EdgeTo(n, neigh graph.Node) graph.Edge {
+	lines, ok := g.edges[n.ID()][neigh.ID()]
+	if !ok || len(lines) == 0 {
+		return nil
+	}
+	return lines[0].Edge
+}
+
+func (g *MutableUndirectedMultigraph) EdgeBetween(n, neigh graph.Node) graph.Edge {
+	return g.EdgeTo(n, neigh)
+}
+
+func (g *MutableUndirectedMultigraph) NodeExists(n graph.Node) bool {
+	_, ok := g.nodeMap[n.ID()]
+	return ok
+}
+
+func (g *MutableUndirectedMultigraph) Degree(n graph.Node) int {
+	if _, ok := g.nodeMap[n.ID()]; !ok {
+		return 0
+	}
+
+	var d int
+	for _, lines := range g.edges[n.ID()] {
+		d += len(lines)
+	}
+	return d
+}
+
+func (g *MutableUndirectedMultigraph) NodeList() []graph.Node {
+	nodes := make([]graph.Node, 0, len(g.nodeMap))
+	for _, n := range g.nodeMap {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Cost returns the weight of e if e is one of g's Lines. Otherwise it
+// reduces the weights of every parallel edge between e.Head() and
+// e.Tail() with g's CostFunc (MinCost by default).
+func (g *MutableUndirectedMultigraph) Cost(e graph.Edge) float64 {
+	lines, ok := g.edges[e.Head().ID()][e.Tail().ID()]
+	if !ok || len(lines) == 0 {
+		return math.Inf(1)
+	}
+
+	if l, ok := e.(Line); ok {
+		for _, we := range lines {
+			if we.Edge.(Line).ID == l.ID {
+				return we.Cost
+			}
+		}
+	}
+
+	costs := make([]float64, len(lines))
+	for i, we := range lines {
+		costs[i] = we.Cost
+	}
+	return g.cost(costs)
+}
+
+func (g *MutableUndirectedMultigraph) EdgeList() []graph.Edge {
+	var edges []graph.Edge
+	seen := make(map[int]bool)
+	for _, neighMap := range g.edges {
+		for _, lines := range neighMap {
+			for _, we := range lines {
+				id := we.Edge.(Line).ID
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				edges = append(edges, we.Edge)
+			}
+		}
+	}
+	return edges
+}
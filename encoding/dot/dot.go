@@ -0,0 +1,23 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dot implements encoding and decoding of graph.Graph values in
+// the GraphViz DOT language.
+package dot
+
+// Attribute is a DOT key/value attribute pair, such as {"color", "red"}.
+type Attribute struct {
+	Key, Value string
+}
+
+// Attributer is implemented by nodes or edges that want to control the
+// attribute list emitted for them by Marshal.
+type Attributer interface {
+	DOTAttributes() []Attribute
+}
+
+// weightKey is the attribute key Marshal uses to record an edge's Cost and
+// Unmarshal looks for when reconstructing it. Marshal always writes this
+// key; UnmarshalWithWeightKey reads costs from a different key instead.
+const weightKey = "weight"
@@ -0,0 +1,173 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"fmt"
+)
+
+type tokenKind int
+
+const (
+	tEOF tokenKind = iota
+	tIdent
+	tLBrace
+	tRBrace
+	tLBracket
+	tRBracket
+	tEquals
+	tSemi
+	tComma
+	tColon
+	tEdgeOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lexer tokenizes a DOT document. It handles bareword and quoted
+// identifiers, HTML-like bracketed strings, C++ and C-style comments, the
+// directed and undirected edge operators, and the small set of
+// punctuation the grammar needs.
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("dot: %s (offset %d)", fmt.Sprintf(format, args...), l.pos)
+}
+
+func (l *lexer) skipSpaceAndComments() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' {
+				l.pos++
+			}
+		case c == '/' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '/':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' {
+				l.pos++
+			}
+		case c == '/' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '*':
+			l.pos += 2
+			for l.pos+1 < len(l.data) && !(l.data[l.pos] == '*' && l.data[l.pos+1] == '/') {
+				l.pos++
+			}
+			l.pos += 2
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpaceAndComments()
+	if l.pos >= len(l.data) {
+		return token{kind: tEOF}, nil
+	}
+
+	c := l.data[l.pos]
+	switch c {
+	case '{':
+		l.pos++
+		return token{kind: tLBrace, text: "{"}, nil
+	case '}':
+		l.pos++
+		return token{kind: tRBrace, text: "}"}, nil
+	case '[':
+		l.pos++
+		return token{kind: tLBracket, text: "["}, nil
+	case ']':
+		l.pos++
+		return token{kind: tRBracket, text: "]"}, nil
+	case '=':
+		l.pos++
+		return token{kind: tEquals, text: "="}, nil
+	case ';':
+		l.pos++
+		return token{kind: tSemi, text: ";"}, nil
+	case ',':
+		l.pos++
+		return token{kind: tComma, text: ","}, nil
+	case ':':
+		l.pos++
+		return token{kind: tColon, text: ":"}, nil
+	case '"':
+		return l.lexQuoted()
+	case '<':
+		return l.lexHTML()
+	case '-':
+		if l.pos+1 < len(l.data) && (l.data[l.pos+1] == '>' || l.data[l.pos+1] == '-') {
+			l.pos += 2
+			return token{kind: tEdgeOp, text: "->"}, nil
+		}
+		return token{}, l.errorf("unexpected %q", c)
+	default:
+		return l.lexIdent()
+	}
+}
+
+func (l *lexer) lexQuoted() (token, error) {
+	start := l.pos
+	l.pos++ // opening quote
+	var buf []byte
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if c == '\\' && l.pos+1 < len(l.data) {
+			buf = append(buf, l.data[l.pos+1])
+			l.pos += 2
+			continue
+		}
+		if c == '"' {
+			l.pos++
+			return token{kind: tIdent, text: string(buf)}, nil
+		}
+		buf = append(buf, c)
+		l.pos++
+	}
+	return token{}, l.errorf("unterminated quoted string starting at %d", start)
+}
+
+// lexHTML consumes a balanced <...> string, treating its raw (unescaped)
+// contents as the identifier's text.
+func (l *lexer) lexHTML() (token, error) {
+	start := l.pos
+	depth := 0
+	for l.pos < len(l.data) {
+		switch l.data[l.pos] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 {
+				l.pos++
+				return token{kind: tIdent, text: string(l.data[start+1 : l.pos-1])}, nil
+			}
+		}
+		l.pos++
+	}
+	return token{}, l.errorf("unterminated HTML string starting at %d", start)
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (l *lexer) lexIdent() (token, error) {
+	start := l.pos
+	for l.pos < len(l.data) && isIdentByte(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		return token{}, l.errorf("unexpected %q", l.data[l.pos])
+	}
+	return token{kind: tIdent, text: string(l.data[start:l.pos])}, nil
+}
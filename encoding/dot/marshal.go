@@ -0,0 +1,157 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+// Marshal renders g as a DOT graph named name. Each line is prefixed with
+// prefix and body statements are additionally indented with indent, so
+// callers can nest the output inside a larger document.
+//
+// Nodes and edges that implement Attributer contribute their returned
+// attributes to the corresponding statement. Edge weights are always
+// emitted under the "weight" key so that Unmarshal can recover them.
+func Marshal(g graph.Graph, name string, prefix, indent string) ([]byte, error) {
+	directed := isDirected(g)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s%s %s {\n", prefix, graphKeyword(directed), quoteID(name))
+
+	nodes := g.NodeList()
+	sort.Sort(byID(nodes))
+	for _, n := range nodes {
+		buf.WriteString(prefix)
+		buf.WriteString(indent)
+		buf.WriteString(quoteID(nodeID(n)))
+		writeAttrs(&buf, attrsOf(n))
+		buf.WriteString(";\n")
+	}
+
+	op := "--"
+	if directed {
+		op = "->"
+	}
+	seen := make(map[[2]int]bool)
+	for _, n := range nodes {
+		for _, s := range g.Successors(n) {
+			if !directed {
+				key := edgeKey(n.ID(), s.ID())
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			e := g.EdgeTo(n, s)
+			buf.WriteString(prefix)
+			buf.WriteString(indent)
+			fmt.Fprintf(&buf, "%s %s %s", quoteID(nodeID(n)), op, quoteID(nodeID(s)))
+			attrs := attrsOf(e)
+			attrs = append(attrs, Attribute{Key: weightKey, Value: strconv.FormatFloat(g.Cost(e), 'g', -1, 64)})
+			writeAttrs(&buf, attrs)
+			buf.WriteString(";\n")
+		}
+	}
+
+	buf.WriteString(prefix)
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// isDirected reports whether g should be rendered as a digraph. Only
+// *concrete.MutableDirectedGraph is known to be directed today; any other
+// graph.Graph is assumed to store edges symmetrically and is rendered as
+// an undirected graph.
+func isDirected(g graph.Graph) bool {
+	switch g.(type) {
+	case *concrete.MutableDirectedGraph:
+		return true
+	default:
+		return false
+	}
+}
+
+func graphKeyword(directed bool) string {
+	if directed {
+		return "digraph"
+	}
+	return "graph"
+}
+
+func attrsOf(v interface{}) []Attribute {
+	if a, ok := v.(Attributer); ok {
+		return append([]Attribute(nil), a.DOTAttributes()...)
+	}
+	return nil
+}
+
+func writeAttrs(buf *bytes.Buffer, attrs []Attribute) {
+	if len(attrs) == 0 {
+		return
+	}
+	buf.WriteString(" [")
+	for i, a := range attrs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%s=%s", quoteID(a.Key), quoteID(a.Value))
+	}
+	buf.WriteString("]")
+}
+
+func nodeID(n graph.Node) string {
+	return strconv.Itoa(n.ID())
+}
+
+func edgeKey(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// quoteID quotes id unless it is already a valid DOT identifier.
+func quoteID(id string) string {
+	if id == "" {
+		return `""`
+	}
+	plain := true
+	for i, r := range id {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			continue
+		}
+		if i > 0 && r >= '0' && r <= '9' {
+			continue
+		}
+		plain = false
+		break
+	}
+	if plain {
+		return id
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range id {
+		if r == '"' || r == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(r)
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+type byID []graph.Node
+
+func (n byID) Len() int           { return len(n) }
+func (n byID) Less(i, j int) bool { return n[i].ID() < n[j].ID() }
+func (n byID) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
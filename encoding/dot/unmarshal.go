@@ -0,0 +1,266 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+// Unmarshal parses a DOT graph from data into dst. Nodes already in dst are
+// left as they are; every node named in data is added as a new node, the
+// first time its name is mentioned, under the lowest integer ID, counting
+// up from the number of nodes already in dst, that no node of dst uses
+// yet. The resulting name-to-node
+// lookup is returned so the caller can recover which node a given name
+// became. Edge costs are read from a "weight" attribute; use
+// UnmarshalWithWeightKey to read them from a differently named attribute.
+func Unmarshal(data []byte, dst *concrete.MutableDirectedGraph) (ids map[string]graph.Node, err error) {
+	return UnmarshalWithWeightKey(data, dst, weightKey)
+}
+
+// UnmarshalWithWeightKey is Unmarshal, but reads edge costs from the
+// attribute named weightAttr instead of "weight". Edges with no such
+// attribute, or with an unparseable value, are added with cost 1.
+func UnmarshalWithWeightKey(data []byte, dst *concrete.MutableDirectedGraph, weightAttr string) (map[string]graph.Node, error) {
+	p := &parser{
+		lexer:      &lexer{data: data},
+		dst:        dst,
+		ids:        make(map[string]graph.Node),
+		weightAttr: weightAttr,
+		nextID:     len(dst.NodeList()),
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.parseGraph(); err != nil {
+		return nil, err
+	}
+	return p.ids, nil
+}
+
+type parser struct {
+	lexer      *lexer
+	tok        token
+	dst        *concrete.MutableDirectedGraph
+	ids        map[string]graph.Node
+	weightAttr string
+	nextID     int
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) expectIdent() (string, error) {
+	if p.tok.kind != tIdent {
+		return "", p.lexer.errorf("expected identifier, got %q", p.tok.text)
+	}
+	text := p.tok.text
+	return text, p.advance()
+}
+
+func (p *parser) eat(kind tokenKind, what string) error {
+	if p.tok.kind != kind {
+		return p.lexer.errorf("expected %s", what)
+	}
+	return p.advance()
+}
+
+func (p *parser) isKeyword(word string) bool {
+	return p.tok.kind == tIdent && strings.EqualFold(p.tok.text, word)
+}
+
+func (p *parser) parseGraph() error {
+	if p.isKeyword("strict") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	if !p.isKeyword("graph") && !p.isKeyword("digraph") {
+		return p.lexer.errorf("expected 'graph' or 'digraph'")
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.tok.kind == tIdent {
+		if err := p.advance(); err != nil { // graph name, discarded
+			return err
+		}
+	}
+	if err := p.eat(tLBrace, "'{'"); err != nil {
+		return err
+	}
+	if err := p.parseStmtList(); err != nil {
+		return err
+	}
+	return p.eat(tRBrace, "'}'")
+}
+
+func (p *parser) parseStmtList() error {
+	for p.tok.kind != tRBrace && p.tok.kind != tEOF {
+		if err := p.parseStmt(); err != nil {
+			return err
+		}
+		for p.tok.kind == tSemi {
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseStmt() error {
+	switch {
+	case p.isKeyword("subgraph"):
+		return p.parseSubgraph()
+	case p.isKeyword("node"), p.isKeyword("edge"), p.isKeyword("graph"):
+		if err := p.advance(); err != nil {
+			return err
+		}
+		_, err := p.parseAttrList()
+		return err
+	default:
+		id, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+		if p.tok.kind == tEdgeOp {
+			return p.parseEdgeStmt(id)
+		}
+		if p.tok.kind == tEquals {
+			// A graph-level attribute assignment, e.g. rankdir=LR;
+			// id names the attribute, not a node, so it is discarded.
+			if err := p.advance(); err != nil {
+				return err
+			}
+			_, err := p.expectIdent()
+			return err
+		}
+		_, err = p.nodeFor(id)
+		if err != nil {
+			return err
+		}
+		_, err = p.parseAttrList()
+		return err
+	}
+}
+
+func (p *parser) parseSubgraph() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.tok.kind == tIdent {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	if err := p.eat(tLBrace, "'{'"); err != nil {
+		return err
+	}
+	if err := p.parseStmtList(); err != nil {
+		return err
+	}
+	return p.eat(tRBrace, "'}'")
+}
+
+func (p *parser) parseEdgeStmt(firstID string) error {
+	head, err := p.nodeFor(firstID)
+	if err != nil {
+		return err
+	}
+
+	var edges []concrete.Edge
+	for p.tok.kind == tEdgeOp {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		tailID, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+		tail, err := p.nodeFor(tailID)
+		if err != nil {
+			return err
+		}
+		edges = append(edges, concrete.Edge{H: head, T: tail})
+		head = tail
+	}
+
+	// DOT applies a trailing attribute list to every edge in the chain,
+	// not just the last hop, so it is parsed once the whole chain is
+	// collected.
+	attrs, err := p.parseAttrList()
+	if err != nil {
+		return err
+	}
+	cost := 1.0
+	for _, a := range attrs {
+		if a.Key == p.weightAttr {
+			if w, err := strconv.ParseFloat(a.Value, 64); err == nil {
+				cost = w
+			}
+		}
+	}
+	for _, e := range edges {
+		p.dst.AddEdgeTo(e, cost)
+	}
+	return nil
+}
+
+func (p *parser) parseAttrList() ([]Attribute, error) {
+	var attrs []Attribute
+	for p.tok.kind == tLBracket {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for p.tok.kind != tRBracket {
+			key, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.eat(tEquals, "'='"); err != nil {
+				return nil, err
+			}
+			value, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			attrs = append(attrs, Attribute{Key: key, Value: value})
+			for p.tok.kind == tComma || p.tok.kind == tSemi {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.advance(); err != nil { // ']'
+			return nil, err
+		}
+	}
+	return attrs, nil
+}
+
+func (p *parser) nodeFor(id string) (graph.Node, error) {
+	if n, ok := p.ids[id]; ok {
+		return n, nil
+	}
+	for p.dst.NodeExists(concrete.Node(p.nextID)) {
+		p.nextID++
+	}
+	n := concrete.Node(p.nextID)
+	p.nextID++
+	p.dst.AddNode(n)
+	p.ids[id] = n
+	return n, nil
+}
@@ -0,0 +1,50 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package graphml implements encoding and decoding of graph.Graph values
+// in the GraphML XML format.
+package graphml
+
+import "encoding/xml"
+
+// Attribute is a GraphML key/value data pair, rendered as a <data> child
+// element keyed by its "key" attribute.
+type Attribute struct {
+	Key, Value string
+}
+
+// Attributer is implemented by nodes or edges that want to control the
+// <data> elements emitted for them by Marshal.
+type Attributer interface {
+	GraphMLAttributes() []Attribute
+}
+
+const weightKey = "weight"
+
+type xmlGraphML struct {
+	XMLName xml.Name `xml:"graphml"`
+	Graph   xmlGraph `xml:"graph"`
+}
+
+type xmlGraph struct {
+	EdgeDefault string    `xml:"edgedefault,attr"`
+	Nodes       []xmlNode `xml:"node"`
+	Edges       []xmlEdge `xml:"edge"`
+}
+
+type xmlNode struct {
+	ID   string    `xml:"id,attr"`
+	Data []xmlData `xml:"data"`
+}
+
+type xmlEdge struct {
+	Source string    `xml:"source,attr"`
+	Target string    `xml:"target,attr"`
+	Data   []xmlData `xml:"data"`
+}
+
+type xmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
@@ -0,0 +1,84 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphml
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+// Marshal renders g as a GraphML document. Nodes and edges that implement
+// Attributer contribute their returned key/value pairs as <data> elements;
+// edge weights are always emitted under the "weight" key so Unmarshal can
+// recover them.
+func Marshal(g graph.Graph) ([]byte, error) {
+	directed := true
+	if _, ok := g.(*concrete.MutableDirectedGraph); !ok {
+		directed = false
+	}
+
+	doc := xmlGraphML{Graph: xmlGraph{EdgeDefault: edgeDefault(directed)}}
+
+	nodes := g.NodeList()
+	for _, n := range nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, xmlNode{
+			ID:   strconv.Itoa(n.ID()),
+			Data: dataFor(n),
+		})
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, n := range nodes {
+		for _, s := range g.Successors(n) {
+			if !directed {
+				key := [2]int{n.ID(), s.ID()}
+				if key[0] > key[1] {
+					key[0], key[1] = key[1], key[0]
+				}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			e := g.EdgeTo(n, s)
+			data := dataFor(e)
+			data = append(data, xmlData{Key: weightKey, Value: strconv.FormatFloat(g.Cost(e), 'g', -1, 64)})
+			doc.Graph.Edges = append(doc.Graph.Edges, xmlEdge{
+				Source: strconv.Itoa(n.ID()),
+				Target: strconv.Itoa(s.ID()),
+				Data:   data,
+			})
+		}
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func edgeDefault(directed bool) string {
+	if directed {
+		return "directed"
+	}
+	return "undirected"
+}
+
+func dataFor(v interface{}) []xmlData {
+	a, ok := v.(Attributer)
+	if !ok {
+		return nil
+	}
+	attrs := a.GraphMLAttributes()
+	data := make([]xmlData, len(attrs))
+	for i, attr := range attrs {
+		data[i] = xmlData{Key: attr.Key, Value: attr.Value}
+	}
+	return data
+}
@@ -0,0 +1,69 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphml
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+// Unmarshal parses a GraphML document from data into dst. Nodes already in
+// dst are left as they are; every node named in data is added as a new
+// node, the first time its name is mentioned, under the lowest integer ID,
+// counting up from the number of nodes already in dst, that no node of dst
+// uses yet. The resulting name-to-node lookup is returned so the caller can recover
+// which node a given name became. Edge costs are read from a "weight"
+// <data> element; use UnmarshalWithWeightKey to read them from a
+// differently keyed element.
+func Unmarshal(data []byte, dst *concrete.MutableDirectedGraph) (map[string]graph.Node, error) {
+	return UnmarshalWithWeightKey(data, dst, weightKey)
+}
+
+// UnmarshalWithWeightKey is Unmarshal, but reads edge costs from the
+// <data> element keyed weightAttr instead of "weight". Edges with no such
+// element, or with an unparseable value, are added with cost 1.
+func UnmarshalWithWeightKey(data []byte, dst *concrete.MutableDirectedGraph, weightAttr string) (map[string]graph.Node, error) {
+	var doc xmlGraphML
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]graph.Node)
+	nextID := len(dst.NodeList())
+	nodeFor := func(id string) graph.Node {
+		if n, ok := ids[id]; ok {
+			return n
+		}
+		for dst.NodeExists(concrete.Node(nextID)) {
+			nextID++
+		}
+		n := concrete.Node(nextID)
+		nextID++
+		dst.AddNode(n)
+		ids[id] = n
+		return n
+	}
+
+	for _, n := range doc.Graph.Nodes {
+		nodeFor(n.ID)
+	}
+	for _, e := range doc.Graph.Edges {
+		head := nodeFor(e.Source)
+		tail := nodeFor(e.Target)
+		cost := 1.0
+		for _, d := range e.Data {
+			if d.Key == weightAttr {
+				if w, err := strconv.ParseFloat(d.Value, 64); err == nil {
+					cost = w
+				}
+			}
+		}
+		dst.AddEdgeTo(concrete.Edge{H: head, T: tail}, cost)
+	}
+	return ids, nil
+}
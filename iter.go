@@ -0,0 +1,49 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+// Nodes is a stateful iterator over a sequence of nodes. It is the
+// allocation-avoiding counterpart to a []Node: a caller that only needs
+// to scan the sequence, or that may stop before reaching its end, can use
+// Nodes without forcing the producer to ever materialize a full slice.
+//
+// A newly returned Nodes is positioned before its first element; Next
+// must be called before the first call to Node.
+type Nodes interface {
+	// Next advances the iterator and reports whether there is a node to
+	// retrieve with Node. Next returns false when the iterator is
+	// exhausted.
+	Next() bool
+
+	// Node returns the node at the iterator's current position. It is
+	// only valid to call Node after a call to Next that returned true.
+	Node() Node
+
+	// Len returns the number of nodes that further calls to Next will
+	// yield. The node at the current position is not counted.
+	Len() int
+
+	// Reset returns the iterator to its initial position.
+	Reset()
+}
+
+// Edges is the Edge analogue of Nodes.
+type Edges interface {
+	// Next advances the iterator and reports whether there is an edge to
+	// retrieve with Edge. Next returns false when the iterator is
+	// exhausted.
+	Next() bool
+
+	// Edge returns the edge at the iterator's current position. It is
+	// only valid to call Edge after a call to Next that returned true.
+	Edge() Edge
+
+	// Len returns the number of edges that further calls to Next will
+	// yield. The edge at the current position is not counted.
+	Len() int
+
+	// Reset returns the iterator to its initial position.
+	Reset()
+}
@@ -0,0 +1,204 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package iterator provides concrete implementations of graph.Nodes and
+// graph.Edges for use by graph.Graph implementations.
+package iterator
+
+import (
+	"reflect"
+
+	"github.com/gonum/graph"
+)
+
+// OrderedNodes iterates over a []graph.Node that has already been
+// materialized by the caller. It exists for API compatibility with code
+// that built a node slice the traditional way (NodeList and similar) but
+// wants to consume it through the graph.Nodes interface.
+type OrderedNodes struct {
+	nodes []graph.Node
+	pos   int
+}
+
+// NewOrderedNodes returns an OrderedNodes over nodes. The caller must not
+// modify nodes while the iterator is in use.
+func NewOrderedNodes(nodes []graph.Node) *OrderedNodes {
+	return &OrderedNodes{nodes: nodes, pos: -1}
+}
+
+func (n *OrderedNodes) Len() int {
+	if n.pos >= len(n.nodes) {
+		return 0
+	}
+	return len(n.nodes) - (n.pos + 1)
+}
+
+func (n *OrderedNodes) Next() bool {
+	if n.pos < len(n.nodes) {
+		n.pos++
+	}
+	return n.pos < len(n.nodes)
+}
+
+func (n *OrderedNodes) Node() graph.Node { return n.nodes[n.pos] }
+func (n *OrderedNodes) Reset()           { n.pos = -1 }
+
+// LazyMapNodes iterates over the keys of m, a map[int]V for any value type
+// V, resolving each one to a graph.Node through lookup only when it is
+// visited. Unlike a []int of pre-collected keys, it never materializes the
+// key set: it walks m's buckets directly through reflection, so a caller
+// that stops early never pays to resolve, or even enumerate, the
+// unvisited remainder.
+type LazyMapNodes struct {
+	m      reflect.Value
+	iter   *reflect.MapIter
+	remain int
+	lookup func(id int) graph.Node
+	cur    graph.Node
+}
+
+// NewLazyMapNodes returns a LazyMapNodes over m, a map[int]V, resolved on
+// demand through lookup.
+func NewLazyMapNodes(m interface{}, lookup func(id int) graph.Node) *LazyMapNodes {
+	v := reflect.ValueOf(m)
+	return &LazyMapNodes{m: v, iter: v.MapRange(), remain: v.Len(), lookup: lookup}
+}
+
+func (n *LazyMapNodes) Len() int { return n.remain }
+
+func (n *LazyMapNodes) Next() bool {
+	if !n.iter.Next() {
+		return false
+	}
+	n.remain--
+	n.cur = n.lookup(int(n.iter.Key().Int()))
+	return true
+}
+
+func (n *LazyMapNodes) Node() graph.Node { return n.cur }
+
+func (n *LazyMapNodes) Reset() {
+	n.iter = n.m.MapRange()
+	n.remain = n.m.Len()
+}
+
+// FilterMapNodes is LazyMapNodes with keys for which skip reports true
+// omitted from iteration. Computing Len exactly still costs one pass over
+// m to count survivors, which NewFilterMapNodes and Reset pay up front;
+// neither allocates a slice to do it.
+type FilterMapNodes struct {
+	m      reflect.Value
+	iter   *reflect.MapIter
+	skip   func(id int) bool
+	lookup func(id int) graph.Node
+	remain int
+	cur    graph.Node
+}
+
+// NewFilterMapNodes returns a FilterMapNodes over m, a map[int]V, resolved
+// on demand through lookup.
+func NewFilterMapNodes(m interface{}, skip func(id int) bool, lookup func(id int) graph.Node) *FilterMapNodes {
+	v := reflect.ValueOf(m)
+	return &FilterMapNodes{m: v, iter: v.MapRange(), skip: skip, lookup: lookup, remain: countSurvivors(v, skip)}
+}
+
+func countSurvivors(m reflect.Value, skip func(id int) bool) int {
+	n := 0
+	for it := m.MapRange(); it.Next(); {
+		if !skip(int(it.Key().Int())) {
+			n++
+		}
+	}
+	return n
+}
+
+func (n *FilterMapNodes) Len() int { return n.remain }
+
+func (n *FilterMapNodes) Next() bool {
+	for n.iter.Next() {
+		id := int(n.iter.Key().Int())
+		if n.skip(id) {
+			continue
+		}
+		n.remain--
+		n.cur = n.lookup(id)
+		return true
+	}
+	return false
+}
+
+func (n *FilterMapNodes) Node() graph.Node { return n.cur }
+
+func (n *FilterMapNodes) Reset() {
+	n.iter = n.m.MapRange()
+	n.remain = countSurvivors(n.m, n.skip)
+}
+
+// ChainNodes concatenates several graph.Nodes into one, exhausting each in
+// order before advancing to the next.
+type ChainNodes struct {
+	iters []graph.Nodes
+	pos   int
+}
+
+// NewChainNodes returns a ChainNodes over iters, visited in order.
+func NewChainNodes(iters ...graph.Nodes) *ChainNodes {
+	return &ChainNodes{iters: iters}
+}
+
+func (c *ChainNodes) Len() int {
+	n := 0
+	for _, it := range c.iters[c.pos:] {
+		n += it.Len()
+	}
+	return n
+}
+
+func (c *ChainNodes) Next() bool {
+	for c.pos < len(c.iters) {
+		if c.iters[c.pos].Next() {
+			return true
+		}
+		c.pos++
+	}
+	return false
+}
+
+func (c *ChainNodes) Node() graph.Node { return c.iters[c.pos].Node() }
+
+func (c *ChainNodes) Reset() {
+	for _, it := range c.iters {
+		it.Reset()
+	}
+	c.pos = 0
+}
+
+// OrderedEdges is the Edges analogue of OrderedNodes.
+type OrderedEdges struct {
+	edges []graph.Edge
+	pos   int
+}
+
+// NewOrderedEdges returns an OrderedEdges over edges. The caller must not
+// modify edges while the iterator is in use.
+func NewOrderedEdges(edges []graph.Edge) *OrderedEdges {
+	return &OrderedEdges{edges: edges, pos: -1}
+}
+
+func (e *OrderedEdges) Len() int {
+	if e.pos >= len(e.edges) {
+		return 0
+	}
+	return len(e.edges) - (e.pos + 1)
+}
+
+func (e *OrderedEdges) Next() bool {
+	if e.pos < len(e.edges) {
+		e.pos++
+	}
+	return e.pos < len(e.edges)
+}
+
+func (e *OrderedEdges) Edge() graph.Edge { return e.edges[e.pos] }
+func (e *OrderedEdges) Reset()           { e.pos = -1 }
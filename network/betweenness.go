@@ -0,0 +1,171 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package network implements vertex centrality measures over graph.Graph.
+package network
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/concrete"
+)
+
+// Option configures Betweenness.
+type Option func(*options)
+
+type options struct {
+	endpoints bool
+}
+
+// EndpointInclusion includes each source node and every node it reaches in
+// their own betweenness scores, following Brandes & Fleischer's
+// endpoint-inclusive definition. Without it, only nodes strictly between a
+// source and a target contribute.
+func EndpointInclusion() Option {
+	return func(o *options) { o.endpoints = true }
+}
+
+// Betweenness returns the betweenness centrality of every node in g,
+// computed with Brandes' algorithm. For each source s, a single-source
+// shortest-path search (Dijkstra, which reduces to BFS when every edge
+// has equal cost) records, for every reachable node v, the number of
+// shortest paths σ[v] and its predecessors P[v] on those paths.
+// Dependencies are then accumulated in reverse order of discovery,
+//
+//	δ[v] = Σ_{w: v∈P[w]} (σ[v]/σ[w])·(1+δ[w])
+//
+// and δ[v] is added into the result for every v≠s. Nodes in a different
+// component from s never appear in its P or σ, so disconnected graphs are
+// handled without special-casing, and self-loops are ignored because a
+// node is never its own predecessor on a shortest path to itself.
+//
+// For an undirected graph, every shortest path is discovered once from
+// each of its endpoints, so the raw sum is halved before being returned.
+func Betweenness(g graph.Graph, opts ...Option) map[int]float64 {
+	var o options
+	for _, apply := range opts {
+		apply(&o)
+	}
+
+	nodes := g.NodeList()
+	centrality := make(map[int]float64, len(nodes))
+	for _, n := range nodes {
+		centrality[n.ID()] = 0
+	}
+
+	for _, s := range nodes {
+		order, pred, sigma := shortestPaths(g, s)
+
+		delta := make(map[int]float64, len(order))
+		for _, v := range order {
+			delta[v.ID()] = 0
+		}
+
+		for i := len(order) - 1; i >= 0; i-- {
+			w := order[i]
+			for _, v := range pred[w.ID()] {
+				delta[v.ID()] += sigma[v.ID()] / sigma[w.ID()] * (1 + delta[w.ID()])
+			}
+			if w.ID() == s.ID() {
+				continue
+			}
+			centrality[w.ID()] += delta[w.ID()]
+			if o.endpoints {
+				centrality[w.ID()]++
+			}
+		}
+		if o.endpoints {
+			// s is itself an endpoint of every shortest path it
+			// originates; credit it once for each other node reached.
+			centrality[s.ID()] += float64(len(order) - 1)
+		}
+	}
+
+	if !isDirected(g) {
+		for id := range centrality {
+			centrality[id] /= 2
+		}
+	}
+
+	return centrality
+}
+
+// shortestPaths runs a single-source Dijkstra search from s over g,
+// returning the nodes in the order they were finalized, each node's
+// shortest-path predecessors, and each node's shortest-path count.
+func shortestPaths(g graph.Graph, s graph.Node) (order []graph.Node, pred map[int][]graph.Node, sigma map[int]float64) {
+	dist := make(map[int]float64)
+	for _, n := range g.NodeList() {
+		dist[n.ID()] = math.Inf(1)
+	}
+	dist[s.ID()] = 0
+
+	pred = make(map[int][]graph.Node)
+	sigma = map[int]float64{s.ID(): 1}
+
+	visited := make(map[int]bool)
+	pq := &nodeHeap{{node: s, dist: 0}}
+	for pq.Len() > 0 {
+		u := heap.Pop(pq).(*heapItem)
+		if visited[u.node.ID()] {
+			continue
+		}
+		visited[u.node.ID()] = true
+		order = append(order, u.node)
+
+		for _, v := range g.Successors(u.node) {
+			if v.ID() == u.node.ID() {
+				continue // ignore self-loops
+			}
+			w := g.Cost(g.EdgeTo(u.node, v))
+			alt := dist[u.node.ID()] + w
+			switch {
+			case alt < dist[v.ID()]:
+				dist[v.ID()] = alt
+				sigma[v.ID()] = sigma[u.node.ID()]
+				pred[v.ID()] = []graph.Node{u.node}
+				heap.Push(pq, &heapItem{node: v, dist: alt})
+			case alt == dist[v.ID()]:
+				sigma[v.ID()] += sigma[u.node.ID()]
+				pred[v.ID()] = append(pred[v.ID()], u.node)
+			}
+		}
+	}
+	return order, pred, sigma
+}
+
+// isDirected reports whether g's edges should be treated as one-way, so
+// that a shortest path discovered from each of its two endpoints is not
+// double-counted. Only the directed concrete graph types are known to be
+// directed today; any other graph.Graph, including the undirected
+// multigraph, is assumed to store edges symmetrically.
+func isDirected(g graph.Graph) bool {
+	switch g.(type) {
+	case *concrete.MutableDirectedGraph, *concrete.MutableDirectedMultigraph:
+		return true
+	default:
+		return false
+	}
+}
+
+type heapItem struct {
+	node graph.Node
+	dist float64
+}
+
+type nodeHeap []*heapItem
+
+func (h nodeHeap) Len() int            { return len(h) }
+func (h nodeHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h nodeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x interface{}) { *h = append(*h, x.(*heapItem)) }
+func (h *nodeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,52 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/gonum/graph/concrete"
+)
+
+// TestBetweennessPath checks the classic three-node path 0->1->2: the only
+// shortest path with an internal node is 0->1->2, so node 1 is the sole
+// node on someone else's shortest path and must score 1; the endpoints
+// score 0.
+func TestBetweennessPath(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	n0, n1, n2 := concrete.Node(0), concrete.Node(1), concrete.Node(2)
+	g.AddEdgeTo(concrete.Edge{H: n0, T: n1}, 1)
+	g.AddEdgeTo(concrete.Edge{H: n1, T: n2}, 1)
+
+	got := Betweenness(g)
+	want := map[int]float64{0: 0, 1: 1, 2: 0}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("Betweenness()[%d] = %v, want %v", id, got[id], w)
+		}
+	}
+}
+
+// TestBetweennessEndpointInclusion checks the same path with
+// EndpointInclusion. Every reachable ordered pair (s,t) contributes 1 to
+// each of s and t in addition to the ordinary internal-node credit, so
+// each node's score is its plain betweenness plus the number of other
+// nodes it reaches plus the number it is reached by: 0 reaches {1,2} (credit 2), 1
+// reaches {2} and is reached by {0} (credit 2), 2 is reached by {0,1}
+// (credit 2).
+func TestBetweennessEndpointInclusion(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	n0, n1, n2 := concrete.Node(0), concrete.Node(1), concrete.Node(2)
+	g.AddEdgeTo(concrete.Edge{H: n0, T: n1}, 1)
+	g.AddEdgeTo(concrete.Edge{H: n1, T: n2}, 1)
+
+	got := Betweenness(g, EndpointInclusion())
+	want := map[int]float64{0: 2, 1: 3, 2: 2}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("Betweenness(EndpointInclusion())[%d] = %v, want %v", id, got[id], w)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// PageRank returns the PageRank of every node in g, computed by power
+// iteration of
+//
+//	x_{k+1} = (1-damping)/N + damping·A^T·D^-1·x_k
+//
+// where A is the weighted adjacency matrix of g, D is the diagonal matrix
+// of out-weighted degrees, and N is the number of nodes. Nodes with zero
+// out-degree ("dangling" nodes) would otherwise leak their mass out of
+// the system; instead, their mass is redistributed uniformly across every
+// node at each iteration, keeping x a probability distribution.
+// Iteration stops once the L1 distance between successive x drops below
+// tol. Disconnected components and self-loops require no special
+// handling: a self-loop is simply one more edge out of (and into) its
+// node, and every node, whatever its component, receives the
+// (1-damping)/N term and the dangling share on top of what its own
+// in-edges carry.
+func PageRank(g graph.Graph, damping, tol float64) map[int]float64 {
+	nodes := g.NodeList()
+	n := len(nodes)
+	if n == 0 {
+		return map[int]float64{}
+	}
+
+	index := make(map[int]int, n)
+	for i, node := range nodes {
+		index[node.ID()] = i
+	}
+
+	outWeight := make([]float64, n)
+	for i, node := range nodes {
+		for _, s := range g.Successors(node) {
+			outWeight[i] += g.Cost(g.EdgeTo(node, s))
+		}
+	}
+
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+
+	base := (1 - damping) / float64(n)
+	for {
+		next := make([]float64, n)
+
+		var dangling float64
+		for i := range x {
+			if outWeight[i] == 0 {
+				dangling += x[i]
+			}
+		}
+		danglingShare := damping * dangling / float64(n)
+		for i := range next {
+			next[i] = base + danglingShare
+		}
+
+		for i, node := range nodes {
+			if outWeight[i] == 0 {
+				continue
+			}
+			for _, s := range g.Successors(node) {
+				w := g.Cost(g.EdgeTo(node, s))
+				next[index[s.ID()]] += damping * x[i] * w / outWeight[i]
+			}
+		}
+
+		var delta float64
+		for i := range x {
+			delta += math.Abs(next[i] - x[i])
+		}
+		x = next
+		if delta < tol {
+			break
+		}
+	}
+
+	result := make(map[int]float64, n)
+	for i, node := range nodes {
+		result[node.ID()] = x[i]
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph/concrete"
+)
+
+const pageRankEpsilon = 1e-6
+
+func closeEnough(a, b float64) bool { return math.Abs(a-b) < pageRankEpsilon }
+
+// TestPageRankSymmetricCycle checks a 2-node reciprocal cycle: by symmetry
+// every node's stationary mass is 1/N regardless of damping, since each
+// node sends its entire weight to the other and gets it straight back.
+func TestPageRankSymmetricCycle(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	n0, n1 := concrete.Node(0), concrete.Node(1)
+	g.AddEdgeTo(concrete.Edge{H: n0, T: n1}, 1)
+	g.AddEdgeTo(concrete.Edge{H: n1, T: n0}, 1)
+
+	got := PageRank(g, 0.85, 1e-10)
+	for id, pr := range got {
+		if !closeEnough(pr, 0.5) {
+			t.Errorf("PageRank()[%d] = %v, want 0.5", id, pr)
+		}
+	}
+}
+
+// TestPageRankDanglingNode checks a 2-node graph where node 1 has no
+// outgoing edges. Solving x = (1-d)/2 + d*x1/2 (node 0, which has no
+// incoming edges, only ever receives redistributed dangling mass) and
+// x1 = (1-d)/2 + d*x1/2 + d*x0 for the fixed point gives the closed forms
+// below, derived independently of the implementation under test.
+func TestPageRankDanglingNode(t *testing.T) {
+	g := concrete.NewMutableDirectedGraph()
+	n0, n1 := concrete.Node(0), concrete.Node(1)
+	g.AddEdgeTo(concrete.Edge{H: n0, T: n1}, 1)
+
+	const damping = 0.85
+	want0 := 1 / (2 + damping)
+	want1 := (1 + damping) / (2 + damping)
+
+	got := PageRank(g, damping, 1e-10)
+	if !closeEnough(got[0], want0) {
+		t.Errorf("PageRank()[0] = %v, want %v", got[0], want0)
+	}
+	if !closeEnough(got[1], want1) {
+		t.Errorf("PageRank()[1] = %v, want %v", got[1], want1)
+	}
+}